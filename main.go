@@ -5,17 +5,10 @@ in an embarassingly parallel way: computing the nth power of the adjacency
 matrix of the graph, and checking that the trace of this matrix is zero[1],
 where n is the dimension of the matrix.
 
-To do this, I am creating a data pipeline:
-
-   1. The initial input will be the original adjacency matrix.
-   2. Each row and column will be parsed, and passed in order to a single
-      channel. Wrapper types are used to preserve row number and column
-      number respectively.
-   3. A series of goroutines consume from this channel, computing the resulting
-      products and placing them in a final channel. A watcher process consumes
-      from this channel, painstakingly pieces each element into the new matrix,
-      and then sends it back.
-   4. The process repeats until all powers have been computed.
+The adjacency matrix is raised to that power over the boolean semiring rather
+than the usual integer one, since cycle detection only needs to know whether
+a path exists between two nodes, not how many - a walk count in a dense graph
+overflows int well before the count itself would matter.
 
 [1] This observation relies on the fact that the elements of the nth power
 of the adjacency matrix represent the number of n-length paths between each
@@ -29,32 +22,53 @@ have your answer.
 package main
 
 import (
-    "log"
-    "encoding/csv"
-	"./matrix"
+	"encoding/csv"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"strings"
+	"log"
+	"os"
 	"strconv"
+	"strings"
+
+	"./matrix"
+	matrixio "./matrix/io"
 )
 
 // This function takes in an adjacency matrix (properties: must be square), and returns
 // whether or not it contains a cycle. This will work for both directed and undirected
 // graphs.
-func isGraphCyclic(adjacencyMatrix matrix.Matrix) bool {
+func isGraphCyclic[T matrix.Numeric](adjacencyMatrix matrix.Matrix[T]) bool {
+
+	// raise the adjacency matrix to A^(A.Rows) over the boolean semiring - we only care
+	// whether a path exists, not how many, and walk counts in a dense graph overflow int well
+	// before that stops mattering.
+	raisedAdjacencyMatrix, err := adjacencyMatrix.ExponentiateBool(adjacencyMatrix.Rows)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// compute the trace and check if it is set, in which case it is cyclic
+	return raisedAdjacencyMatrix.Trace()
+}
 
-	// raise the adjacency matrix to A^len(A.Rows). 
-	raisedAdjacencyMatrix := adjacencyMatrix.Exponentiate(adjacencyMatrix.Rows)
+// isGraphCyclicSparse is isGraphCyclic for an adjacency matrix that arrived as a SparseMatrix -
+// the common case once the CLI is pointed at a real graph instead of a small dense CSV.
+func isGraphCyclicSparse[T matrix.Numeric](adjacencyMatrix matrix.SparseMatrix[T]) bool {
 
-	// compute the trace and check if it is not zero, in which case it is cyclic
-	return raisedAdjacencyMatrix.Trace() != 0
+	raisedAdjacencyMatrix, err := adjacencyMatrix.ExponentiateBool(adjacencyMatrix.Rows)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return raisedAdjacencyMatrix.Trace()
 }
 
-// return a slice of slice of ints from a slice of slice of strings.
-func convertContentsToInt(contents [][]string) [][]int {
+// tryConvertContentsToInt parses contents as ints, returning ok=false on the first entry that
+// doesn't parse so the caller can fall back to float64 instead of failing outright.
+func tryConvertContentsToInt(contents [][]string) (newContents [][]int, ok bool) {
 
-    newContents := make([][]int, len(contents))
+	newContents = make([][]int, len(contents))
 
 	for lineIndex, line := range contents {
 
@@ -63,13 +77,36 @@ func convertContentsToInt(contents [][]string) [][]int {
 		for entryIndex, entry := range line {
 
 			intifiedEntry, err := strconv.Atoi(entry)
+			if err != nil {
+				return nil, false
+			}
+
+			newContents[lineIndex][entryIndex] = intifiedEntry
+		}
+	}
+
+	return newContents, true
+}
+
+// convertContentsToFloat returns a slice of slice of float64s from a slice of slice of strings.
+func convertContentsToFloat(contents [][]string) [][]float64 {
+
+	newContents := make([][]float64, len(contents))
+
+	for lineIndex, line := range contents {
+
+		newContents[lineIndex] = make([]float64, len(line))
+
+		for entryIndex, entry := range line {
 
-			if (err != nil) {
+			floatifiedEntry, err := strconv.ParseFloat(entry, 64)
+
+			if err != nil {
 				log.Fatal(err)
 				panic(err)
 			}
 
-			newContents[lineIndex][entryIndex] = intifiedEntry
+			newContents[lineIndex][entryIndex] = floatifiedEntry
 
 		}
 	}
@@ -77,36 +114,47 @@ func convertContentsToInt(contents [][]string) [][]int {
 	return newContents
 }
 
-func main() {
+// readDenseCSV handles the original dense-CSV path: the whole file is parsed into a matrix of
+// adjacency entries, preferring an IntMatrix when every entry parses as an int and falling
+// back to an F64Matrix otherwise, so a CSV of weights or probabilities doesn't have to be
+// pre-truncated.
+func readDenseCSV(filename string) {
 
-	filename := flag.String("file-location", "", "Path to a CSV file containing our desired matrix")
-	flag.Parse()
+	fileContents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-	if *filename != "" {
+	reader := csv.NewReader(strings.NewReader(string(fileContents)))
+	elements, err := reader.ReadAll()
+	if err != nil {
+		log.Fatal(err)
+		panic(err)
+	}
 
-		// read the file
-		fileContents, err := ioutil.ReadFile(*filename)
-		if err != nil {
-			log.Fatal(err)
-		}
+	matrixRows, matrixColumns := len(elements), len(elements)
+	if matrixRows != matrixColumns {
+		fmt.Println("WARN: Only square matrices allowed.")
+	}
+
+	if intElements, ok := tryConvertContentsToInt(elements); ok {
 
-		// parse the file
-		reader := csv.NewReader(strings.NewReader(string(fileContents)))
-		elements, err := reader.ReadAll()
+		constructedMatrix, err := matrix.NewMatrix(matrixRows, matrixColumns, intElements)
 		if err != nil {
 			log.Fatal(err)
 			panic(err)
 		}
 
-		// elements is of type [][]string, but we need [][]int
-		matrixElements := convertContentsToInt(elements)
-		matrixRows, matrixColumns := len(matrixElements), len(matrixElements)
-		if matrixRows != matrixColumns {
-			fmt.Println("WARN: Only square matrices allowed.")
-		}
+		fmt.Println("Original matrix:")
+		fmt.Println(constructedMatrix)
+		fmt.Println("Is it cyclic?")
+		fmt.Println(isGraphCyclic(constructedMatrix))
+
+	} else {
 
-		constructedMatrix, err := matrix.NewMatrix(matrixRows, matrixColumns, matrixElements)
+		floatElements := convertContentsToFloat(elements)
 
+		constructedMatrix, err := matrix.NewMatrix(matrixRows, matrixColumns, floatElements)
 		if err != nil {
 			log.Fatal(err)
 			panic(err)
@@ -116,6 +164,56 @@ func main() {
 		fmt.Println(constructedMatrix)
 		fmt.Println("Is it cyclic?")
 		fmt.Println(isGraphCyclic(constructedMatrix))
+	}
+}
+
+// readSparse handles the Matrix Market and edge-list paths: both are streamed straight off an
+// open file into a SparseMatrix, which is what real (i.e. sparse) graphs should be using
+// anyway.
+func readSparse(filename, format string) {
+
+	file, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var constructedMatrix matrix.SparseMatrix[float64]
+
+	switch format {
+	case "mtx":
+		constructedMatrix, err = matrixio.ReadMatrixMarket(file)
+	case "edgelist":
+		constructedMatrix, err = matrixio.ReadEdgeList(file)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if constructedMatrix.Rows != constructedMatrix.Columns {
+		fmt.Println("WARN: Only square matrices allowed.")
+	}
+
+	fmt.Println("Original matrix:")
+	fmt.Println(constructedMatrix)
+	fmt.Println("Is it cyclic?")
+	fmt.Println(isGraphCyclicSparse(constructedMatrix))
+}
+
+func main() {
+
+	filename := flag.String("file-location", "", "Path to a matrix file: dense CSV, Matrix Market (.mtx), or edge-list CSV (.edges/.edgelist)")
+	flag.Parse()
+
+	if *filename != "" {
+
+		switch format := matrixio.DetectFormat(*filename); format {
+		case "dense":
+			readDenseCSV(*filename)
+		default:
+			readSparse(*filename, format)
+		}
 
 	}
 
@@ -0,0 +1,146 @@
+package matrix
+
+import "errors"
+
+// wordBits is the number of boolean columns packed into a single uint64 word.
+const wordBits = 64
+
+// BoolMatrix is a square matrix over the boolean semiring (+ is OR, * is AND), stored as one
+// bitset per row rather than one int per entry. It exists for reachability-style computations
+// such as cycle detection, where what matters is only whether a path exists, not how many -
+// a path count in a dense graph overflows int well before the count itself becomes useless.
+type BoolMatrix struct {
+	Size int
+	Rows [][]uint64
+}
+
+// newBoolMatrix allocates a zeroed size x size BoolMatrix.
+func newBoolMatrix(size int) BoolMatrix {
+	words := (size + wordBits - 1) / wordBits
+	rows := make([][]uint64, size)
+	for i := range rows {
+		rows[i] = make([]uint64, words)
+	}
+	return BoolMatrix{Size: size, Rows: rows}
+}
+
+func (b BoolMatrix) set(i, j int) {
+	b.Rows[i][j/wordBits] |= 1 << uint(j%wordBits)
+}
+
+// At reports whether entry (i, j) is set.
+func (b BoolMatrix) At(i, j int) bool {
+	return b.Rows[i][j/wordBits]&(1<<uint(j%wordBits)) != 0
+}
+
+// identityBool returns the size x size boolean identity matrix.
+func identityBool(size int) BoolMatrix {
+	identity := newBoolMatrix(size)
+	for i := 0; i < size; i++ {
+		identity.set(i, i)
+	}
+	return identity
+}
+
+// transpose returns a copy of b with rows and columns swapped. MultiplyBool uses this to turn
+// "column j of the right-hand matrix" into a row it can AND against directly.
+func (b BoolMatrix) transpose() BoolMatrix {
+	t := newBoolMatrix(b.Size)
+	for i := 0; i < b.Size; i++ {
+		for j := 0; j < b.Size; j++ {
+			if b.At(i, j) {
+				t.set(j, i)
+			}
+		}
+	}
+	return t
+}
+
+// MultiplyBool computes the boolean-semiring product of b and other: entry (i, j) of the
+// result is set iff there is some k with b[i][k] and other[k][j] both set. Transposing other
+// once up front turns each entry's "is there a shared k" test into a word-wise AND between two
+// same-length bitsets, with no popcount needed - we only care whether any word of the AND is
+// nonzero, not how many bits are set.
+func (b BoolMatrix) MultiplyBool(other BoolMatrix) (BoolMatrix, error) {
+
+	if b.Size != other.Size {
+		return BoolMatrix{}, errors.New("matrix: incompatible dimensions for multiplication")
+	}
+
+	otherT := other.transpose()
+	result := newBoolMatrix(b.Size)
+
+	for i := 0; i < b.Size; i++ {
+		row := b.Rows[i]
+		for j := 0; j < b.Size; j++ {
+			col := otherT.Rows[j]
+			for w := range row {
+				if row[w]&col[w] != 0 {
+					result.set(i, j)
+					break
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Trace reports whether any diagonal entry of b is set.
+func (b BoolMatrix) Trace() bool {
+	for i := 0; i < b.Size; i++ {
+		if b.At(i, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExponentiateBool computes the power-th boolean-semiring power of initialMatrix using binary
+// exponentiation, the same way Exponentiate does for the integer case. It's the version
+// isGraphCyclic should use: reachability only cares whether a path exists, and working over
+// the boolean semiring keeps every intermediate entry a single bit regardless of how large
+// power or the graph gets, where the integer version's walk counts would overflow.
+func (initialMatrix Matrix[T]) ExponentiateBool(power int) (BoolMatrix, error) {
+
+	if power <= 0 {
+		return BoolMatrix{}, errors.New("Only integer positive non-zero powers are allowed")
+	}
+
+	if initialMatrix.Rows != initialMatrix.Columns {
+		return BoolMatrix{}, errors.New("Only square matrices can be exponentiated")
+	}
+
+	var zero T
+	size := initialMatrix.Rows
+	base := newBoolMatrix(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			if initialMatrix.At(i, j) != zero {
+				base.set(i, j)
+			}
+		}
+	}
+
+	result := identityBool(size)
+
+	for power > 0 {
+		if power&1 == 1 {
+			next, err := result.MultiplyBool(base)
+			if err != nil {
+				return BoolMatrix{}, err
+			}
+			result = next
+		}
+
+		squaredBase, err := base.MultiplyBool(base)
+		if err != nil {
+			return BoolMatrix{}, err
+		}
+		base = squaredBase
+
+		power >>= 1
+	}
+
+	return result, nil
+}
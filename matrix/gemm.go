@@ -0,0 +1,97 @@
+package matrix
+
+import "errors"
+
+// Transpose indicates whether Gemm should operate on a matrix as given or on its transpose.
+type Transpose int
+
+const (
+	NoTrans Transpose = iota
+	Trans
+)
+
+// opShape returns the effective (rows, columns) of m once tr has been applied, without
+// actually materialising the transpose.
+func opShape[T Numeric](m Matrix[T], tr Transpose) (rows, columns int) {
+	if tr == Trans {
+		return m.Columns, m.Rows
+	}
+	return m.Rows, m.Columns
+}
+
+// opAt returns op(m)[i][j], where op is the identity or transpose according to tr.
+func opAt[T Numeric](m Matrix[T], tr Transpose, i, j int) T {
+	if tr == Trans {
+		return m.At(j, i)
+	}
+	return m.At(i, j)
+}
+
+// Gemm computes C = alpha*op(A)*op(B) + beta*C in place, where op(X) is X itself when the
+// corresponding Transpose is NoTrans and X's transpose when it is Trans. It mirrors the shape
+// of gonum's Dgemm: c must already be allocated to the shape of op(A)*op(B), and is scaled by
+// beta (rather than replaced) before op(A)*op(B) is accumulated into it, so repeated calls
+// with beta=1 let a caller build up a running sum - e.g. the I + A + A^2 + ... series used for
+// reachability - without allocating an intermediate matrix per term.
+func Gemm[T Numeric](tA, tB Transpose, alpha T, a, b Matrix[T], beta T, c *Matrix[T]) error {
+
+	aRows, aCols := opShape(a, tA)
+	bRows, bCols := opShape(b, tB)
+
+	if aCols != bRows {
+		return errors.New("matrix: incompatible dimensions for multiplication")
+	}
+
+	if c.Rows != aRows || c.Columns != bCols {
+		return errors.New("matrix: destination matrix has the wrong shape")
+	}
+
+	var zero T
+	one := T(1)
+
+	// Quick return: nothing from A*B contributes, and C is left untouched.
+	if alpha == zero && beta == one {
+		return nil
+	}
+
+	if beta == zero {
+		for i := 0; i < c.Rows; i++ {
+			for j := 0; j < c.Columns; j++ {
+				c.Set(i, j, zero)
+			}
+		}
+	} else if beta != one {
+		for i := 0; i < c.Rows; i++ {
+			for j := 0; j < c.Columns; j++ {
+				c.Set(i, j, c.At(i, j)*beta)
+			}
+		}
+	}
+
+	if alpha == zero {
+		return nil
+	}
+
+	// The common case - no transposes, unit scale, and a zeroed destination - is exactly what
+	// MultiplyInto's blocked, worker-pool implementation computes, so hand it off there instead
+	// of re-deriving the fast path.
+	if tA == NoTrans && tB == NoTrans && alpha == one && beta == zero {
+		return a.MultiplyInto(b, c)
+	}
+
+	m, n, k := aRows, bCols, aCols
+
+	for i := 0; i < m; i++ {
+		for kk := 0; kk < k; kk++ {
+			aVal := alpha * opAt(a, tA, i, kk)
+			if aVal == zero {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				c.Set(i, j, c.At(i, j)+aVal*opAt(b, tB, kk, j))
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+// Package matrixio reads and writes the sparse matrix formats the cycle-detection CLI accepts
+// besides dense CSV: Matrix Market coordinate files and edge-list CSVs. Both are read straight
+// from an io.Reader a line/record at a time rather than being slurped fully into memory first,
+// since the whole point of supporting them is handling graphs too big for a dense adjacency
+// matrix to be practical.
+package matrixio
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"../../matrix"
+)
+
+// ReadMatrixMarket parses the Matrix Market coordinate format: any number of comment lines
+// starting with '%', then a "rows columns nonzeros" dimensions line, then one "row column
+// [value]" line per entry. Indices are 1-based in the format and are converted to 0-based.
+// A missing value (the pattern variant of the format) is treated as 1. If the banner line
+// declares the symmetric variant, each off-diagonal entry is mirrored across the diagonal,
+// since that variant stores only one triangle on disk and expects readers to fill in the rest.
+func ReadMatrixMarket(r io.Reader) (matrix.SparseMatrix[float64], error) {
+
+	scanner := bufio.NewScanner(r)
+
+	var rows, columns, nnz int
+	dimsParsed := false
+	symmetric := false
+
+	var rowIdx, colIdx []int
+	var values []float64
+	entriesRead := 0
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			if strings.HasPrefix(line, "%%MatrixMarket") {
+				symmetric = strings.Contains(strings.ToLower(line), "symmetric")
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		if !dimsParsed {
+			if len(fields) < 3 {
+				return matrix.SparseMatrix[float64]{}, errors.New("matrixio: malformed Matrix Market dimensions line")
+			}
+
+			var err error
+			if rows, err = strconv.Atoi(fields[0]); err != nil {
+				return matrix.SparseMatrix[float64]{}, err
+			}
+			if columns, err = strconv.Atoi(fields[1]); err != nil {
+				return matrix.SparseMatrix[float64]{}, err
+			}
+			if nnz, err = strconv.Atoi(fields[2]); err != nil {
+				return matrix.SparseMatrix[float64]{}, err
+			}
+
+			dimsParsed = true
+			continue
+		}
+
+		if len(fields) < 2 {
+			return matrix.SparseMatrix[float64]{}, errors.New("matrixio: malformed Matrix Market entry line")
+		}
+
+		row, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return matrix.SparseMatrix[float64]{}, err
+		}
+		col, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return matrix.SparseMatrix[float64]{}, err
+		}
+
+		value := 1.0
+		if len(fields) >= 3 {
+			if value, err = strconv.ParseFloat(fields[2], 64); err != nil {
+				return matrix.SparseMatrix[float64]{}, err
+			}
+		}
+
+		rowIdx = append(rowIdx, row-1)
+		colIdx = append(colIdx, col-1)
+		values = append(values, value)
+		entriesRead++
+
+		// The symmetric variant of the format stores only the lower (or upper) triangle and
+		// expects the reader to mirror each off-diagonal entry across the diagonal.
+		if symmetric && row != col {
+			rowIdx = append(rowIdx, col-1)
+			colIdx = append(colIdx, row-1)
+			values = append(values, value)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return matrix.SparseMatrix[float64]{}, err
+	}
+
+	if !dimsParsed {
+		return matrix.SparseMatrix[float64]{}, errors.New("matrixio: missing Matrix Market dimensions line")
+	}
+
+	if entriesRead != nnz {
+		return matrix.SparseMatrix[float64]{}, fmt.Errorf("matrixio: dimensions line promised %d entries, found %d", nnz, entriesRead)
+	}
+
+	return matrix.NewSparseMatrixFromTriplets(rows, columns, rowIdx, colIdx, values)
+}
+
+// WriteMatrixMarket writes m in the Matrix Market coordinate real general format that
+// ReadMatrixMarket reads back.
+func WriteMatrixMarket(w io.Writer, m matrix.SparseMatrix[float64]) error {
+
+	if _, err := fmt.Fprintln(w, "%%MatrixMarket matrix coordinate real general"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "%d %d %d\n", m.Rows, m.Columns, len(m.Values)); err != nil {
+		return err
+	}
+
+	for i := 0; i < m.Rows; i++ {
+		for idx := m.RowPtr[i]; idx < m.RowPtr[i+1]; idx++ {
+			if _, err := fmt.Fprintf(w, "%d %d %g\n", i+1, m.ColIdx[idx]+1, m.Values[idx]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReadEdgeList parses a "src,dst[,weight]" CSV, one edge per row, and returns the corresponding
+// adjacency matrix as a SparseMatrix. A missing weight column defaults to 1. The matrix is
+// sized to (max node index seen)+1 on each axis, so nodes are expected to be dense 0-based
+// integers, the same convention the dense CSV loader already assumes.
+func ReadEdgeList(r io.Reader) (matrix.SparseMatrix[float64], error) {
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	var rowIdx, colIdx []int
+	var values []float64
+	maxIndex := -1
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return matrix.SparseMatrix[float64]{}, err
+		}
+
+		if len(record) < 2 {
+			return matrix.SparseMatrix[float64]{}, errors.New("matrixio: edge list rows need at least src,dst")
+		}
+
+		src, err := strconv.Atoi(strings.TrimSpace(record[0]))
+		if err != nil {
+			return matrix.SparseMatrix[float64]{}, err
+		}
+		dst, err := strconv.Atoi(strings.TrimSpace(record[1]))
+		if err != nil {
+			return matrix.SparseMatrix[float64]{}, err
+		}
+
+		weight := 1.0
+		if len(record) >= 3 && strings.TrimSpace(record[2]) != "" {
+			if weight, err = strconv.ParseFloat(strings.TrimSpace(record[2]), 64); err != nil {
+				return matrix.SparseMatrix[float64]{}, err
+			}
+		}
+
+		rowIdx = append(rowIdx, src)
+		colIdx = append(colIdx, dst)
+		values = append(values, weight)
+
+		if src > maxIndex {
+			maxIndex = src
+		}
+		if dst > maxIndex {
+			maxIndex = dst
+		}
+	}
+
+	size := maxIndex + 1
+	return matrix.NewSparseMatrixFromTriplets(size, size, rowIdx, colIdx, values)
+}
+
+// WriteEdgeList writes m as a "src,dst,weight" CSV, one row per stored entry, that ReadEdgeList
+// reads back.
+func WriteEdgeList(w io.Writer, m matrix.SparseMatrix[float64]) error {
+
+	writer := csv.NewWriter(w)
+
+	for i := 0; i < m.Rows; i++ {
+		for idx := m.RowPtr[i]; idx < m.RowPtr[i+1]; idx++ {
+			record := []string{
+				strconv.Itoa(i),
+				strconv.Itoa(m.ColIdx[idx]),
+				strconv.FormatFloat(m.Values[idx], 'g', -1, 64),
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// DetectFormat maps a filename's extension to the matrix format the CLI should read it as:
+// ".mtx" for Matrix Market, ".edges"/".edgelist" for edge-list CSV, and everything else
+// (notably plain ".csv") for the existing dense CSV path.
+func DetectFormat(filename string) string {
+	switch {
+	case strings.HasSuffix(filename, ".mtx"):
+		return "mtx"
+	case strings.HasSuffix(filename, ".edges"), strings.HasSuffix(filename, ".edgelist"):
+		return "edgelist"
+	default:
+		return "dense"
+	}
+}
@@ -0,0 +1,141 @@
+package matrixio
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"../../matrix"
+)
+
+func assertSparseEqual(t *testing.T, got, want matrix.SparseMatrix[float64]) {
+	t.Helper()
+	if got.Rows != want.Rows || got.Columns != want.Columns {
+		t.Fatalf("shape mismatch: got %dx%d, want %dx%d", got.Rows, got.Columns, want.Rows, want.Columns)
+	}
+	for i := 0; i < want.Rows; i++ {
+		for j := 0; j < want.Columns; j++ {
+			if got.At(i, j) != want.At(i, j) {
+				t.Fatalf("entry (%d,%d): got %v, want %v", i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	want, err := matrix.NewSparseMatrixFromTriplets(3, 3, []int{0, 1, 2}, []int{1, 2, 0}, []float64{1, 2.5, 3})
+	if err != nil {
+		t.Fatalf("NewSparseMatrixFromTriplets: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteMatrixMarket(&buf, want); err != nil {
+		t.Fatalf("WriteMatrixMarket: %v", err)
+	}
+
+	got, err := ReadMatrixMarket(&buf)
+	if err != nil {
+		t.Fatalf("ReadMatrixMarket: %v", err)
+	}
+
+	assertSparseEqual(t, got, want)
+}
+
+func TestReadMatrixMarketPattern(t *testing.T) {
+	input := strings.NewReader("%%MatrixMarket matrix coordinate pattern general\n3 3 2\n1 2\n2 3\n")
+
+	got, err := ReadMatrixMarket(input)
+	if err != nil {
+		t.Fatalf("ReadMatrixMarket: %v", err)
+	}
+
+	if got.At(0, 1) != 1 || got.At(1, 2) != 1 {
+		t.Fatalf("pattern entries should default to 1: got matrix %+v", got)
+	}
+}
+
+func TestReadMatrixMarketSymmetricMirrorsOffDiagonal(t *testing.T) {
+	input := strings.NewReader("%%MatrixMarket matrix coordinate pattern symmetric\n3 3 1\n1 2\n")
+
+	got, err := ReadMatrixMarket(input)
+	if err != nil {
+		t.Fatalf("ReadMatrixMarket: %v", err)
+	}
+
+	if got.At(0, 1) != 1 || got.At(1, 0) != 1 {
+		t.Fatalf("symmetric entries should be mirrored across the diagonal: got matrix %+v", got)
+	}
+}
+
+func TestReadMatrixMarketNnzMismatch(t *testing.T) {
+	// Dimensions line promises 2 entries but only 1 follows.
+	input := strings.NewReader("%%MatrixMarket matrix coordinate real general\n3 3 2\n1 2 1\n")
+
+	if _, err := ReadMatrixMarket(input); err == nil {
+		t.Fatal("expected an error when nnz doesn't match the number of entries, got nil")
+	}
+}
+
+func TestReadMatrixMarketMalformedDimensions(t *testing.T) {
+	input := strings.NewReader("%%MatrixMarket matrix coordinate real general\n3 3\n")
+
+	if _, err := ReadMatrixMarket(input); err == nil {
+		t.Fatal("expected an error for a malformed dimensions line, got nil")
+	}
+}
+
+func TestEdgeListRoundTrip(t *testing.T) {
+	want, err := matrix.NewSparseMatrixFromTriplets(3, 3, []int{0, 1, 2}, []int{1, 2, 0}, []float64{1, 1, 1})
+	if err != nil {
+		t.Fatalf("NewSparseMatrixFromTriplets: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteEdgeList(&buf, want); err != nil {
+		t.Fatalf("WriteEdgeList: %v", err)
+	}
+
+	got, err := ReadEdgeList(&buf)
+	if err != nil {
+		t.Fatalf("ReadEdgeList: %v", err)
+	}
+
+	assertSparseEqual(t, got, want)
+}
+
+func TestReadEdgeListDefaultsWeightToOne(t *testing.T) {
+	input := strings.NewReader("0,1\n1,2\n")
+
+	got, err := ReadEdgeList(input)
+	if err != nil {
+		t.Fatalf("ReadEdgeList: %v", err)
+	}
+
+	if got.At(0, 1) != 1 || got.At(1, 2) != 1 {
+		t.Fatalf("edges without a weight column should default to 1: got matrix %+v", got)
+	}
+}
+
+func TestReadEdgeListRejectsMissingDst(t *testing.T) {
+	input := strings.NewReader("0\n")
+
+	if _, err := ReadEdgeList(input); err == nil {
+		t.Fatal("expected an error for a row missing the dst column, got nil")
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := map[string]string{
+		"graph.mtx":      "mtx",
+		"graph.edges":    "edgelist",
+		"graph.edgelist": "edgelist",
+		"graph.csv":      "dense",
+		"graph":          "dense",
+	}
+
+	for filename, want := range cases {
+		if got := DetectFormat(filename); got != want {
+			t.Errorf("DetectFormat(%q) = %q, want %q", filename, got, want)
+		}
+	}
+}
@@ -1,159 +1,292 @@
 package matrix
 
-import "errors"
-
-type Element struct {
-	RowIndex int
-	ColIndex int
-	Value    int
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// Numeric constrains the element type a Matrix can hold.
+type Numeric interface {
+	~int | ~int64 | ~float32 | ~float64
 }
 
-type Matrix struct {
+// Matrix is a dense, generic matrix. Entries are stored in a single flat, row-major slice
+// rather than a slice of slices, with Stride giving the distance (in elements) between the
+// start of one row and the next - this is the same lda/ldb/ldc convention gonum's Dgemm uses.
+// Stride is always >= Columns; the two differ only for a Matrix produced by Slice, which
+// shares its backing Data with the matrix it was sliced from so that taking a submatrix view
+// is zero-copy.
+type Matrix[T Numeric] struct {
 	Rows    int
 	Columns int
-	Entries [][]int
+	Stride  int
+	Data    []T
 }
 
+// IntMatrix and F64Matrix are the two backends most callers reach for: IntMatrix for exact
+// walk/entry counts on small inputs, F64Matrix once those counts would overflow int or the
+// data is naturally continuous.
+type IntMatrix = Matrix[int]
+type F64Matrix = Matrix[float64]
+
+// blockSize is the tile width used by the blocked multiplier below: C is partitioned into
+// blockSize x blockSize tiles, and each tile is accumulated over blockSize-wide panels of A
+// and B, which keeps the panels a tile touches resident in L1 while it's being computed.
+const blockSize = 64
+
+// parallelThreshold is the rough FLOP count (m*n*k) below which Multiply just runs serially.
+// Below this, the cost of spinning up the worker pool and coordinating a WaitGroup dwarfs the
+// work being done - naive per-element goroutines have been observed to slow small matmuls
+// down by an order of magnitude or more.
+const parallelThreshold = 100000
+
 // A constructor function for the exported matrix type that offers entry initialisation as a bonus.
 // params: rows: The number of rows in the matrix. Must be > 0.
 // params: columns: The number of columns in the matrix. Must be > 0/
 // params: entries: An actual initializer slice within a slice that will populate the matrix for you.
 //         Note that, if provided, the matrix will ignore this if it detects an inconsistency between
 //         the provided `rows` or the provided `columns` and the number of rows/columns in `entries`.
-func NewMatrix(rows int, columns int, entries ...[][]int) (Matrix, error) {
+func NewMatrix[T Numeric](rows int, columns int, entries ...[][]T) (Matrix[T], error) {
 
 	if rows <= 0 || columns <= 0 {
-		return Matrix{}, errors.New("Both rows and columns must be greater than 0")
+		return Matrix[T]{}, errors.New("Both rows and columns must be greater than 0")
 	}
 
 	if len(entries) == 0 {
-		m := Matrix{rows, columns, make([][]int, rows)}
-		for i, _ := range m.Entries {
-			m.Entries[i] = make([]int, columns)
-		}
-		return m, nil
+		return Matrix[T]{Rows: rows, Columns: columns, Stride: columns, Data: make([]T, rows*columns)}, nil
 	}
 
 	if len(entries[0]) != rows || len(entries[0][0]) != columns {
-		return Matrix{}, errors.New("Provided rows and columns don't match with provided entries")
+		return Matrix[T]{}, errors.New("Provided rows and columns don't match with provided entries")
+	}
+
+	m := Matrix[T]{Rows: rows, Columns: columns, Stride: columns, Data: make([]T, rows*columns)}
+	for i, row := range entries[0] {
+		copy(m.Data[i*m.Stride:i*m.Stride+columns], row)
 	}
 
-	return Matrix{rows, columns, entries[0]}, nil
+	return m, nil
 }
 
-// A function that lets you compute integer positive non-zero powers of a matrix.
-func (initialMatrix Matrix) Exponentiate(power int) Matrix {
+// At returns the entry at (row, column).
+func (m Matrix[T]) At(row, column int) T {
+	return m.Data[row*m.Stride+column]
+}
 
-	if power <= 0 {
-		panic(errors.New("Only integer positive non-zero powers are allowed"))
-	}
+// Set writes v to the entry at (row, column).
+func (m Matrix[T]) Set(row, column int, v T) {
+	m.Data[row*m.Stride+column] = v
+}
+
+// Slice returns a zero-copy view onto the submatrix spanning rows [i0, i1) and columns
+// [j0, j1): the returned Matrix shares m's backing Data and keeps m's Stride, so writes
+// through the view are writes through m. This is what MultiplyInto partitions a matrix into
+// tiles and panels with.
+func (m Matrix[T]) Slice(i0, i1, j0, j1 int) Matrix[T] {
 
-	currentMatrix := initialMatrix
+	if i0 < 0 || j0 < 0 || i1 < i0 || j1 < j0 || i1 > m.Rows || j1 > m.Columns {
+		panic(errors.New("matrix: slice bounds out of range"))
+	}
 
-	// multiply matrix by itself in parallel
-	for i := 1; i < power; i++ {
-		currentMatrix = initialMatrix.Multiply(currentMatrix)
+	if i1 == i0 || j1 == j0 {
+		return Matrix[T]{Rows: i1 - i0, Columns: j1 - j0, Stride: m.Stride}
 	}
 
-	return currentMatrix
+	lo := i0*m.Stride + j0
+	hi := (i1-1)*m.Stride + j1
+	return Matrix[T]{
+		Rows:    i1 - i0,
+		Columns: j1 - j0,
+		Stride:  m.Stride,
+		Data:    m.Data[lo:hi:hi],
+	}
 }
 
-// A function to left-multiply two matrices together. Panics if the dimensions of the provided matrices
-// are not sufficient to provide a final value. Internally, it makes use of goroutines to obtain
-// asynchronous multiplication - copies of matrix elements are sent to two input channels, are multiplied
-// together by a reader, and piped to a final output channel which then pieces together the final matrix.
-// This isn't an improvement over just multiplying them serially, but it serves the purpose of allowing me
-// to experiment with goroutines. In the future, I may reiterate on this to implement true parallel multiplication
-// - recursively multiply block component submatrices of our chosen matrix.
+// Identity returns the size x size identity matrix.
+func Identity[T Numeric](size int) (Matrix[T], error) {
 
-func (self Matrix) Multiply(secondMatrix Matrix) Matrix {
+	identity, err := NewMatrix[T](size, size)
+	if err != nil {
+		return Matrix[T]{}, err
+	}
 
-	if self.Rows != secondMatrix.Columns {
-		panic(errors.New("Matrices are not compatible for matrix multiplication - check their dimensions."))
+	for i := 0; i < size; i++ {
+		identity.Set(i, i, T(1))
 	}
 
-	// declare the shape of our final result
-	computedMatrix, err := NewMatrix(self.Rows, self.Columns)
+	return identity, nil
+}
 
-	if err != nil {
-		panic(err)
-	}
+// A function that lets you compute integer positive non-zero powers of a matrix, via binary
+// exponentiation: result starts at I, base starts at initialMatrix, and on each iteration we
+// fold base into result whenever the current low bit of power is set before squaring base and
+// shifting power right. That's one squaring per bit of power plus one fold per set bit -
+// O(log2(power)) multiplications instead of power-1.
+func (initialMatrix Matrix[T]) Exponentiate(power int) Matrix[T] {
 
-	// all channels need to be buffered in order to avoid deadlock. We pick the total number of elements we'll ever send.
-	// If unbuffered, deadlock arises because in `products`, we wait for someone to be listening to the final output channel
-	// while program is still trying to send to the first channel before it starts listening to final output, causing all
-	// goroutines to get stuck.
+	if power <= 0 {
+		panic(errors.New("Only integer positive non-zero powers are allowed"))
+	}
 
-	size := computedMatrix.Rows * computedMatrix.Rows * computedMatrix.Rows // Note: this also supports non-square matrices
-	firstInputChannel, secondInputChannel := make(chan Element, size), make(chan Element, size)
-	finalOutputChannel := make(chan Element, size)
+	if initialMatrix.Rows != initialMatrix.Columns {
+		panic(errors.New("Only square matrices can be exponentiated"))
+	}
 
-	// start listening for individual elements to process - this goroutine will be blocked until we start sending data below.
-	go computeProducts(firstInputChannel, secondInputChannel, finalOutputChannel)
+	result, err := Identity[T](initialMatrix.Rows)
+	if err != nil {
+		panic(err)
+	}
 
-	// Iterate through every element A_ij in the first matrix. For every ij, send all elements of the jth row to the second
-	// input channel and len(A[i]) copies of A_ij to the first input channel. This way, we can compute only the pairs we really
-	// need to obtain. The order we flush to these channels ensures we get back our data consistently.
+	base := initialMatrix
 
-	for i, _ := range self.Entries {
-		for j, firstValue := range self.Entries[i] {
-			for k, secondValue := range secondMatrix.Entries[j] {
-				firstInputChannel <- Element{i, j, firstValue}
-				secondInputChannel <- Element{j, k, secondValue}
+	for power > 0 {
+		if power&1 == 1 {
+			next, err := NewMatrix[T](result.Rows, base.Columns)
+			if err != nil {
+				panic(err)
 			}
+			if err := Gemm(NoTrans, NoTrans, T(1), result, base, T(0), &next); err != nil {
+				panic(err)
+			}
+			result = next
+		}
+
+		squaredBase, err := NewMatrix[T](base.Rows, base.Columns)
+		if err != nil {
+			panic(err)
+		}
+		if err := Gemm(NoTrans, NoTrans, T(1), base, base, T(0), &squaredBase); err != nil {
+			panic(err)
 		}
+		base = squaredBase
+
+		power >>= 1
 	}
 
-	// close both channels so that our goroutines can exit once needed.
-	close(firstInputChannel)
-	close(secondInputChannel)
+	return result
+}
+
+// Multiply left-multiplies self by secondMatrix and returns the result. Unlike the old
+// channel-based implementation, it reports incompatible dimensions as an error rather than
+// panicking, so callers that can recover from a shape mismatch aren't forced to unwind one.
+// It's a thin convenience wrapper around Gemm(NoTrans, NoTrans, 1, self, secondMatrix, 0, &c).
+func (self Matrix[T]) Multiply(secondMatrix Matrix[T]) (Matrix[T], error) {
 
-	// read in the final computed values and just build our final computed matrix.
-	for computedElement := range finalOutputChannel {
-		computedMatrix.Entries[computedElement.RowIndex][computedElement.ColIndex] += computedElement.Value
+	computedMatrix, err := NewMatrix[T](self.Rows, secondMatrix.Columns)
+	if err != nil {
+		return Matrix[T]{}, err
 	}
 
-	return computedMatrix
+	if err := Gemm(NoTrans, NoTrans, T(1), self, secondMatrix, T(0), &computedMatrix); err != nil {
+		return Matrix[T]{}, err
+	}
 
+	return computedMatrix, nil
 }
 
-// A go routine that simply reads from two channels, computes products and then send the result elsewhere. Only used
-// internally.
-func computeProducts(firstInputChannel <-chan Element, secondInputChannel <-chan Element, finalOutputChannel chan<- Element) {
+// MultiplyInto computes self*secondMatrix and writes the result into dst, which must already
+// be shaped self.Rows x secondMatrix.Columns. This lets callers that multiply repeatedly
+// reuse the same backing allocation across calls instead of allocating a fresh Matrix every
+// time.
+//
+// The computation is cache-blocked along the lines of gonum's Dgemm: dst is partitioned into
+// blockSize x blockSize tiles via Slice, and each tile is accumulated over blockSize-wide
+// panels of A and B - also taken with Slice - using the standard i-k-j inner loop ordering.
+// Tiles are independent of one another, so for large enough inputs they're handed out to a
+// small worker pool sized to runtime.GOMAXPROCS(0); below parallelThreshold the whole thing
+// runs serially on the calling goroutine instead, since the blocking and pool coordination
+// would cost more than the naive loops on small inputs.
+func (self Matrix[T]) MultiplyInto(secondMatrix Matrix[T], dst *Matrix[T]) error {
+
+	if self.Columns != secondMatrix.Rows {
+		return errors.New("matrix: incompatible dimensions for multiplication")
+	}
 
-	for {
+	if dst.Rows != self.Rows || dst.Columns != secondMatrix.Columns {
+		return errors.New("matrix: destination matrix has the wrong shape")
+	}
 
-		x, firstChannelIsOpen := <-firstInputChannel
-		y, secondChannelIsOpen := <-secondInputChannel
+	m, n, k := self.Rows, secondMatrix.Columns, self.Columns
+	var zero T
 
-		if firstChannelIsOpen && secondChannelIsOpen {
-			// compute individual product and tag the matrix element the product should belong to.
-			finalOutputChannel <- Element{RowIndex: x.RowIndex, ColIndex: y.ColIndex, Value: x.Value * y.Value}
+	for i := 0; i < dst.Rows; i++ {
+		for j := 0; j < dst.Columns; j++ {
+			dst.Set(i, j, zero)
+		}
+	}
 
-		} else {
+	multiplyTile := func(iStart, iEnd, jStart, jEnd int) {
+		cTile := dst.Slice(iStart, iEnd, jStart, jEnd)
 
-			close(finalOutputChannel)
-			break
+		for kStart := 0; kStart < k; kStart += blockSize {
+			kEnd := kStart + blockSize
+			if kEnd > k {
+				kEnd = k
+			}
 
+			aPanel := self.Slice(iStart, iEnd, kStart, kEnd)
+			bPanel := secondMatrix.Slice(kStart, kEnd, jStart, jEnd)
+
+			for i := 0; i < aPanel.Rows; i++ {
+				aRow := aPanel.Data[i*aPanel.Stride : i*aPanel.Stride+aPanel.Columns]
+				cRow := cTile.Data[i*cTile.Stride : i*cTile.Stride+cTile.Columns]
+				for kk := 0; kk < aPanel.Columns; kk++ {
+					aVal := aRow[kk]
+					if aVal == zero {
+						continue
+					}
+					bRow := bPanel.Data[kk*bPanel.Stride : kk*bPanel.Stride+bPanel.Columns]
+					for j := 0; j < cTile.Columns; j++ {
+						cRow[j] += aVal * bRow[j]
+					}
+				}
+			}
 		}
 	}
-}
 
-// Computes the trace of our matrix type
-func (self Matrix) Trace() int {
+	if m*n*k < parallelThreshold {
+		multiplyTile(0, m, 0, n)
+		return nil
+	}
 
-	trace := 0
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
 
-	// iterate through the entries and sum the diagonal values
-	for i, _ := range self.Entries {
-		for j, value := range self.Entries[i] {
-			if i == j {
-				trace += value
+	for iStart := 0; iStart < m; iStart += blockSize {
+		iEnd := iStart + blockSize
+		if iEnd > m {
+			iEnd = m
+		}
+		for jStart := 0; jStart < n; jStart += blockSize {
+			jEnd := jStart + blockSize
+			if jEnd > n {
+				jEnd = n
 			}
+
+			wg.Add(1)
+			workers <- struct{}{}
+			go func(iStart, iEnd, jStart, jEnd int) {
+				defer wg.Done()
+				defer func() { <-workers }()
+				multiplyTile(iStart, iEnd, jStart, jEnd)
+			}(iStart, iEnd, jStart, jEnd)
 		}
 	}
 
-	return trace
+	wg.Wait()
+
+	return nil
 }
 
+// Computes the trace of our matrix type
+func (self Matrix[T]) Trace() T {
+
+	var trace T
 
+	for i := 0; i < self.Rows && i < self.Columns; i++ {
+		trace += self.At(i, i)
+	}
+
+	return trace
+}
@@ -0,0 +1,239 @@
+package matrix
+
+import "testing"
+
+// naiveMultiply is a deliberately unoptimized reference implementation used to check the
+// blocked/parallel Multiply against, so a bug in the tiling or worker-pool plumbing doesn't
+// just reproduce itself in both the implementation and the test.
+func naiveMultiply[T Numeric](a, b Matrix[T]) Matrix[T] {
+	result, err := NewMatrix[T](a.Rows, b.Columns)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < b.Columns; j++ {
+			var sum T
+			for k := 0; k < a.Columns; k++ {
+				sum += a.At(i, k) * b.At(k, j)
+			}
+			result.Set(i, j, sum)
+		}
+	}
+	return result
+}
+
+// fillDeterministic fills an n x n matrix with a repeatable, non-trivial pattern of positive,
+// negative and zero entries without depending on math/rand.
+func fillDeterministic(n int) IntMatrix {
+	m, err := NewMatrix[int](n, n)
+	if err != nil {
+		panic(err)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m.Set(i, j, (i*7+j*13+1)%11-5)
+		}
+	}
+	return m
+}
+
+func assertMatrixEqual[T Numeric](t *testing.T, got, want Matrix[T]) {
+	t.Helper()
+	if got.Rows != want.Rows || got.Columns != want.Columns {
+		t.Fatalf("shape mismatch: got %dx%d, want %dx%d", got.Rows, got.Columns, want.Rows, want.Columns)
+	}
+	for i := 0; i < want.Rows; i++ {
+		for j := 0; j < want.Columns; j++ {
+			if got.At(i, j) != want.At(i, j) {
+				t.Fatalf("entry (%d,%d): got %v, want %v", i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestMultiplySerialMatchesNaive(t *testing.T) {
+	// 4x4x4 = 64, well under parallelThreshold, so this exercises the serial path.
+	a := fillDeterministic(4)
+	b := fillDeterministic(4)
+
+	got, err := a.Multiply(b)
+	if err != nil {
+		t.Fatalf("Multiply returned error: %v", err)
+	}
+
+	assertMatrixEqual(t, got, naiveMultiply(a, b))
+}
+
+func TestMultiplyIntoParallelMatchesNaive(t *testing.T) {
+	// 50x50x50 = 125000 > parallelThreshold, so MultiplyInto must take the worker-pool path.
+	const n = 50
+	a := fillDeterministic(n)
+	b := fillDeterministic(n)
+
+	if a.Rows*b.Columns*a.Columns < parallelThreshold {
+		t.Fatalf("test fixture too small to exercise the parallel path")
+	}
+
+	dst, err := NewMatrix[int](n, n)
+	if err != nil {
+		t.Fatalf("NewMatrix: %v", err)
+	}
+
+	if err := a.MultiplyInto(b, &dst); err != nil {
+		t.Fatalf("MultiplyInto returned error: %v", err)
+	}
+
+	assertMatrixEqual(t, dst, naiveMultiply(a, b))
+}
+
+func TestMultiplyIncompatibleDimensions(t *testing.T) {
+	a, _ := NewMatrix[int](2, 3)
+	b, _ := NewMatrix[int](2, 2)
+
+	if _, err := a.Multiply(b); err == nil {
+		t.Fatal("expected an error for incompatible dimensions, got nil")
+	}
+}
+
+func TestGemmTransposeAlphaBeta(t *testing.T) {
+	a, err := NewMatrix(2, 3, [][]int{{1, 2, 3}, {4, 5, 6}})
+	if err != nil {
+		t.Fatalf("NewMatrix a: %v", err)
+	}
+	b, err := NewMatrix(2, 3, [][]int{{1, 0, 1}, {0, 1, 1}})
+	if err != nil {
+		t.Fatalf("NewMatrix b: %v", err)
+	}
+
+	// C = 2*A^T*B + 3*C, computed by hand below.
+	c, err := NewMatrix(3, 3, [][]int{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}})
+	if err != nil {
+		t.Fatalf("NewMatrix c: %v", err)
+	}
+
+	if err := Gemm(Trans, NoTrans, 2, a, b, 3, &c); err != nil {
+		t.Fatalf("Gemm returned error: %v", err)
+	}
+
+	// A^T*B:
+	//   [1 4]   [1 0 1]   [1  4  5]
+	//   [2 5] * [0 1 1] = [2  5  7]
+	//   [3 6]             [3  6  9]
+	want, err := NewMatrix(3, 3, [][]int{
+		{2*1 + 3, 2*4 + 3, 2*5 + 3},
+		{2*2 + 3, 2*5 + 3, 2*7 + 3},
+		{2*3 + 3, 2*6 + 3, 2*9 + 3},
+	})
+	if err != nil {
+		t.Fatalf("NewMatrix want: %v", err)
+	}
+
+	assertMatrixEqual(t, c, want)
+}
+
+func TestGemmIncompatibleDimensions(t *testing.T) {
+	a, _ := NewMatrix[int](2, 3)
+	b, _ := NewMatrix[int](2, 2)
+	c, _ := NewMatrix[int](2, 2)
+
+	if err := Gemm(NoTrans, NoTrans, 1, a, b, 0, &c); err == nil {
+		t.Fatal("expected an error for incompatible dimensions, got nil")
+	}
+}
+
+func TestExponentiateMatchesRepeatedMultiply(t *testing.T) {
+	m := fillDeterministic(3)
+
+	got := m.Exponentiate(5)
+
+	want := m
+	for i := 1; i < 5; i++ {
+		want = naiveMultiply(want, m)
+	}
+
+	assertMatrixEqual(t, got, want)
+}
+
+func TestExponentiateIdentityStaysIdentity(t *testing.T) {
+	identity, err := Identity[int](4)
+	if err != nil {
+		t.Fatalf("Identity: %v", err)
+	}
+
+	got := identity.Exponentiate(10)
+
+	assertMatrixEqual(t, got, identity)
+}
+
+func TestSliceIsZeroCopyView(t *testing.T) {
+	m := fillDeterministic(4)
+
+	view := m.Slice(1, 3, 1, 3)
+	if view.Rows != 2 || view.Columns != 2 {
+		t.Fatalf("unexpected view shape: %dx%d", view.Rows, view.Columns)
+	}
+
+	if view.At(0, 0) != m.At(1, 1) || view.At(1, 1) != m.At(2, 2) {
+		t.Fatalf("view entries don't line up with the source matrix")
+	}
+
+	view.Set(0, 0, 999)
+	if m.At(1, 1) != 999 {
+		t.Fatalf("writing through the view didn't mutate the source matrix: got %v", m.At(1, 1))
+	}
+}
+
+func TestExponentiateBoolDetectsCycle(t *testing.T) {
+	// 0 -> 1 -> 2 -> 0: a 3-cycle.
+	cyclic, err := NewMatrix(3, 3, [][]int{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}})
+	if err != nil {
+		t.Fatalf("NewMatrix: %v", err)
+	}
+
+	result, err := cyclic.ExponentiateBool(cyclic.Rows)
+	if err != nil {
+		t.Fatalf("ExponentiateBool: %v", err)
+	}
+	if !result.Trace() {
+		t.Fatal("expected a cyclic graph to have a set trace")
+	}
+}
+
+func TestExponentiateBoolAcyclic(t *testing.T) {
+	// 0 -> 1 -> 2, a simple chain with no way back to any node.
+	acyclic, err := NewMatrix(3, 3, [][]int{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}})
+	if err != nil {
+		t.Fatalf("NewMatrix: %v", err)
+	}
+
+	result, err := acyclic.ExponentiateBool(acyclic.Rows)
+	if err != nil {
+		t.Fatalf("ExponentiateBool: %v", err)
+	}
+	if result.Trace() {
+		t.Fatal("expected an acyclic graph to have an unset trace")
+	}
+}
+
+func TestExponentiateBoolRejectsNonSquare(t *testing.T) {
+	m, err := NewMatrix[int](2, 3)
+	if err != nil {
+		t.Fatalf("NewMatrix: %v", err)
+	}
+
+	if _, err := m.ExponentiateBool(2); err == nil {
+		t.Fatal("expected an error for a non-square matrix, got nil")
+	}
+}
+
+func TestSliceOutOfRangePanics(t *testing.T) {
+	m := fillDeterministic(3)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Slice to panic on out-of-range bounds")
+		}
+	}()
+
+	m.Slice(0, 4, 0, 2)
+}
@@ -0,0 +1,146 @@
+package matrix
+
+import (
+	"errors"
+	"sort"
+)
+
+// SparseMatrix is a matrix stored in compressed sparse row (CSR) form: RowPtr[i] is the index
+// into ColIdx/Values where row i's entries begin (and RowPtr[i+1] where they end), ColIdx
+// holds the column of each stored entry, and Values holds the corresponding value. This is the
+// natural representation for an adjacency matrix built from a graph, which for any graph worth
+// calling sparse has far fewer edges than Rows*Columns.
+type SparseMatrix[T Numeric] struct {
+	Rows    int
+	Columns int
+	RowPtr  []int
+	ColIdx  []int
+	Values  []T
+}
+
+// NewSparseMatrixFromTriplets builds a SparseMatrix from parallel (row, column, value)
+// triplets - the form both the Matrix Market reader and the edge-list reader naturally
+// produce. Triplets don't need to arrive sorted; duplicate (row, column) pairs are summed,
+// which is what you want for an edge list built from a multigraph.
+func NewSparseMatrixFromTriplets[T Numeric](rows, columns int, rowIdx, colIdx []int, values []T) (SparseMatrix[T], error) {
+
+	if rows <= 0 || columns <= 0 {
+		return SparseMatrix[T]{}, errors.New("Both rows and columns must be greater than 0")
+	}
+
+	if len(rowIdx) != len(colIdx) || len(rowIdx) != len(values) {
+		return SparseMatrix[T]{}, errors.New("matrix: triplet slices must have equal length")
+	}
+
+	type entry struct {
+		col   int
+		value T
+	}
+
+	byRow := make([][]entry, rows)
+	for idx := range rowIdx {
+		r, c := rowIdx[idx], colIdx[idx]
+		if r < 0 || r >= rows || c < 0 || c >= columns {
+			return SparseMatrix[T]{}, errors.New("matrix: triplet index out of bounds")
+		}
+		byRow[r] = append(byRow[r], entry{c, values[idx]})
+	}
+
+	rowPtr := make([]int, rows+1)
+	var colIdxOut []int
+	var valuesOut []T
+
+	for r := 0; r < rows; r++ {
+		row := byRow[r]
+		sort.Slice(row, func(i, j int) bool { return row[i].col < row[j].col })
+
+		for i := 0; i < len(row); {
+			col := row[i].col
+			var sum T
+			for i < len(row) && row[i].col == col {
+				sum += row[i].value
+				i++
+			}
+			colIdxOut = append(colIdxOut, col)
+			valuesOut = append(valuesOut, sum)
+		}
+
+		rowPtr[r+1] = len(colIdxOut)
+	}
+
+	return SparseMatrix[T]{Rows: rows, Columns: columns, RowPtr: rowPtr, ColIdx: colIdxOut, Values: valuesOut}, nil
+}
+
+// At returns the entry at (row, column), which is the zero value of T if no entry is stored there.
+func (s SparseMatrix[T]) At(row, column int) T {
+	for idx := s.RowPtr[row]; idx < s.RowPtr[row+1]; idx++ {
+		if s.ColIdx[idx] == column {
+			return s.Values[idx]
+		}
+	}
+	var zero T
+	return zero
+}
+
+// Trace sums the stored entries on the main diagonal.
+func (s SparseMatrix[T]) Trace() T {
+	var trace T
+	n := s.Rows
+	if s.Columns < n {
+		n = s.Columns
+	}
+	for i := 0; i < n; i++ {
+		trace += s.At(i, i)
+	}
+	return trace
+}
+
+// Multiply computes s*other and returns the result, itself stored as a SparseMatrix, using a
+// row-wise SpGEMM (Gustavson's algorithm): for each row i of s, for every nonzero s[i][k] we
+// scatter-accumulate aVal*other[k][:] into a dense length-Columns workspace, remembering which
+// columns got touched so we only have to walk those when compacting the row back into CSR -
+// not the whole workspace.
+func (s SparseMatrix[T]) Multiply(other SparseMatrix[T]) (SparseMatrix[T], error) {
+
+	if s.Columns != other.Rows {
+		return SparseMatrix[T]{}, errors.New("matrix: incompatible dimensions for multiplication")
+	}
+
+	rowPtr := make([]int, s.Rows+1)
+	var colIdxOut []int
+	var valuesOut []T
+
+	accumulator := make([]T, other.Columns)
+	marked := make([]bool, other.Columns)
+	touched := make([]int, 0, other.Columns)
+
+	for i := 0; i < s.Rows; i++ {
+		touched = touched[:0]
+
+		for idx := s.RowPtr[i]; idx < s.RowPtr[i+1]; idx++ {
+			k := s.ColIdx[idx]
+			aVal := s.Values[idx]
+
+			for bIdx := other.RowPtr[k]; bIdx < other.RowPtr[k+1]; bIdx++ {
+				j := other.ColIdx[bIdx]
+				if !marked[j] {
+					marked[j] = true
+					touched = append(touched, j)
+				}
+				accumulator[j] += aVal * other.Values[bIdx]
+			}
+		}
+
+		sort.Ints(touched)
+		for _, j := range touched {
+			colIdxOut = append(colIdxOut, j)
+			valuesOut = append(valuesOut, accumulator[j])
+			accumulator[j] = 0
+			marked[j] = false
+		}
+
+		rowPtr[i+1] = len(colIdxOut)
+	}
+
+	return SparseMatrix[T]{Rows: s.Rows, Columns: other.Columns, RowPtr: rowPtr, ColIdx: colIdxOut, Values: valuesOut}, nil
+}
@@ -0,0 +1,138 @@
+package matrix
+
+import "testing"
+
+// denseToSparse converts a dense Matrix to a SparseMatrix with the same entries, storing every
+// entry (including zeros) as a triplet - good enough for building test fixtures, even if a real
+// sparse matrix wouldn't store its zeros.
+func denseToSparse[T Numeric](m Matrix[T]) SparseMatrix[T] {
+	var rowIdx, colIdx []int
+	var values []T
+	for i := 0; i < m.Rows; i++ {
+		for j := 0; j < m.Columns; j++ {
+			rowIdx = append(rowIdx, i)
+			colIdx = append(colIdx, j)
+			values = append(values, m.At(i, j))
+		}
+	}
+	sparse, err := NewSparseMatrixFromTriplets(m.Rows, m.Columns, rowIdx, colIdx, values)
+	if err != nil {
+		panic(err)
+	}
+	return sparse
+}
+
+func assertSparseMatchesDense[T Numeric](t *testing.T, got SparseMatrix[T], want Matrix[T]) {
+	t.Helper()
+	if got.Rows != want.Rows || got.Columns != want.Columns {
+		t.Fatalf("shape mismatch: got %dx%d, want %dx%d", got.Rows, got.Columns, want.Rows, want.Columns)
+	}
+	for i := 0; i < want.Rows; i++ {
+		for j := 0; j < want.Columns; j++ {
+			if got.At(i, j) != want.At(i, j) {
+				t.Fatalf("entry (%d,%d): got %v, want %v", i, j, got.At(i, j), want.At(i, j))
+			}
+		}
+	}
+}
+
+func TestSparseMultiplyMatchesDense(t *testing.T) {
+	a := fillDeterministic(5)
+	b := fillDeterministic(5)
+
+	wantDense, err := a.Multiply(b)
+	if err != nil {
+		t.Fatalf("dense Multiply: %v", err)
+	}
+
+	sparseA := denseToSparse(a)
+	sparseB := denseToSparse(b)
+
+	got, err := sparseA.Multiply(sparseB)
+	if err != nil {
+		t.Fatalf("sparse Multiply: %v", err)
+	}
+
+	assertSparseMatchesDense(t, got, wantDense)
+}
+
+func TestSparseMultiplyIncompatibleDimensions(t *testing.T) {
+	a, err := NewSparseMatrixFromTriplets[int](2, 3, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewSparseMatrixFromTriplets a: %v", err)
+	}
+	b, err := NewSparseMatrixFromTriplets[int](2, 2, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewSparseMatrixFromTriplets b: %v", err)
+	}
+
+	if _, err := a.Multiply(b); err == nil {
+		t.Fatal("expected an error for incompatible dimensions, got nil")
+	}
+}
+
+func TestSparseFromTripletsSumsDuplicates(t *testing.T) {
+	sparse, err := NewSparseMatrixFromTriplets(2, 2, []int{0, 0, 1}, []int{1, 1, 0}, []int{3, 4, 5})
+	if err != nil {
+		t.Fatalf("NewSparseMatrixFromTriplets: %v", err)
+	}
+
+	if got := sparse.At(0, 1); got != 7 {
+		t.Fatalf("duplicate entries should be summed: got %v, want 7", got)
+	}
+	if got := sparse.At(1, 0); got != 5 {
+		t.Fatalf("got %v, want 5", got)
+	}
+}
+
+func TestExponentiateBoolSparseMatchesDense(t *testing.T) {
+	cases := []struct {
+		name    string
+		entries [][]int
+		cyclic  bool
+	}{
+		{"cyclic", [][]int{{0, 1, 0}, {0, 0, 1}, {1, 0, 0}}, true},
+		{"acyclic", [][]int{{0, 1, 0}, {0, 0, 1}, {0, 0, 0}}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dense, err := NewMatrix(3, 3, c.entries)
+			if err != nil {
+				t.Fatalf("NewMatrix: %v", err)
+			}
+
+			sparse := denseToSparse(dense)
+
+			result, err := sparse.ExponentiateBool(sparse.Rows)
+			if err != nil {
+				t.Fatalf("ExponentiateBool: %v", err)
+			}
+
+			if result.Trace() != c.cyclic {
+				t.Fatalf("got Trace()=%v, want %v", result.Trace(), c.cyclic)
+			}
+
+			denseResult, err := dense.ExponentiateBool(dense.Rows)
+			if err != nil {
+				t.Fatalf("dense ExponentiateBool: %v", err)
+			}
+			for i := 0; i < dense.Rows; i++ {
+				for j := 0; j < dense.Columns; j++ {
+					if result.At(i, j) != denseResult.At(i, j) {
+						t.Fatalf("entry (%d,%d): sparse %v, dense %v", i, j, result.At(i, j), denseResult.At(i, j))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSparseTrace(t *testing.T) {
+	dense := fillDeterministic(4)
+	sparse := denseToSparse(dense)
+
+	if got, want := sparse.Trace(), dense.Trace(); got != want {
+		t.Fatalf("Trace(): got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package matrix
+
+import (
+	"errors"
+	"sort"
+)
+
+// SparseBoolMatrix is the sparse counterpart of BoolMatrix: a square matrix over the boolean
+// semiring (+ is OR, * is AND), stored in CSR form with the column of each set entry but no
+// value array, since on the boolean semiring presence is the only thing worth storing.
+type SparseBoolMatrix struct {
+	Size   int
+	RowPtr []int
+	ColIdx []int
+}
+
+// identitySparseBool returns the size x size boolean identity matrix.
+func identitySparseBool(size int) SparseBoolMatrix {
+	rowPtr := make([]int, size+1)
+	colIdx := make([]int, size)
+	for i := 0; i < size; i++ {
+		colIdx[i] = i
+		rowPtr[i+1] = i + 1
+	}
+	return SparseBoolMatrix{Size: size, RowPtr: rowPtr, ColIdx: colIdx}
+}
+
+// At reports whether entry (row, column) is set.
+func (b SparseBoolMatrix) At(row, column int) bool {
+	for idx := b.RowPtr[row]; idx < b.RowPtr[row+1]; idx++ {
+		if b.ColIdx[idx] == column {
+			return true
+		}
+	}
+	return false
+}
+
+// Trace reports whether any diagonal entry of b is set.
+func (b SparseBoolMatrix) Trace() bool {
+	for i := 0; i < b.Size; i++ {
+		if b.At(i, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// MultiplyBool computes the boolean-semiring product of b and other via the same Gustavson's
+// algorithm SparseMatrix.Multiply uses: for each row i of b, for every set column k, mark
+// every set column of other's row k in a length-Size workspace, then compact the marked
+// columns into the result row in sorted order. There's no value to accumulate on the boolean
+// semiring, so marking a column is all a contribution ever does.
+func (b SparseBoolMatrix) MultiplyBool(other SparseBoolMatrix) (SparseBoolMatrix, error) {
+
+	if b.Size != other.Size {
+		return SparseBoolMatrix{}, errors.New("matrix: incompatible dimensions for multiplication")
+	}
+
+	rowPtr := make([]int, b.Size+1)
+	var colIdxOut []int
+
+	marked := make([]bool, b.Size)
+	touched := make([]int, 0, b.Size)
+
+	for i := 0; i < b.Size; i++ {
+		touched = touched[:0]
+
+		for idx := b.RowPtr[i]; idx < b.RowPtr[i+1]; idx++ {
+			k := b.ColIdx[idx]
+			for oIdx := other.RowPtr[k]; oIdx < other.RowPtr[k+1]; oIdx++ {
+				j := other.ColIdx[oIdx]
+				if !marked[j] {
+					marked[j] = true
+					touched = append(touched, j)
+				}
+			}
+		}
+
+		sort.Ints(touched)
+		colIdxOut = append(colIdxOut, touched...)
+		for _, j := range touched {
+			marked[j] = false
+		}
+
+		rowPtr[i+1] = len(colIdxOut)
+	}
+
+	return SparseBoolMatrix{Size: b.Size, RowPtr: rowPtr, ColIdx: colIdxOut}, nil
+}
+
+// ExponentiateBool computes the power-th boolean-semiring power of initialMatrix using binary
+// exponentiation, mirroring Matrix.ExponentiateBool. Adjacency
+// matrices built for cycle detection are almost always sparse, so this is the path the CLI
+// takes once it's loaded a SparseMatrix instead of a dense one.
+func (initialMatrix SparseMatrix[T]) ExponentiateBool(power int) (SparseBoolMatrix, error) {
+
+	if power <= 0 {
+		return SparseBoolMatrix{}, errors.New("Only integer positive non-zero powers are allowed")
+	}
+
+	if initialMatrix.Rows != initialMatrix.Columns {
+		return SparseBoolMatrix{}, errors.New("Only square matrices can be exponentiated")
+	}
+
+	var zero T
+	size := initialMatrix.Rows
+
+	rowPtr := make([]int, size+1)
+	var colIdxOut []int
+	for i := 0; i < size; i++ {
+		for idx := initialMatrix.RowPtr[i]; idx < initialMatrix.RowPtr[i+1]; idx++ {
+			if initialMatrix.Values[idx] != zero {
+				colIdxOut = append(colIdxOut, initialMatrix.ColIdx[idx])
+			}
+		}
+		rowPtr[i+1] = len(colIdxOut)
+	}
+	base := SparseBoolMatrix{Size: size, RowPtr: rowPtr, ColIdx: colIdxOut}
+
+	result := identitySparseBool(size)
+
+	for power > 0 {
+		if power&1 == 1 {
+			next, err := result.MultiplyBool(base)
+			if err != nil {
+				return SparseBoolMatrix{}, err
+			}
+			result = next
+		}
+
+		squaredBase, err := base.MultiplyBool(base)
+		if err != nil {
+			return SparseBoolMatrix{}, err
+		}
+		base = squaredBase
+
+		power >>= 1
+	}
+
+	return result, nil
+}